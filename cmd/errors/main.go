@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mUsman2003/golang_practice/pkg/apperr"
+)
+
+// ErrNotReady is a sentinel error that callers can match with errors.Is
+var ErrNotReady = errors.New("not ready")
+
+// StepError records which step failed and wraps the cause
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return "step " + e.Step + ": " + e.Err.Error()
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+func prepare() error {
+	// apperr.Wrap adds a machine-readable code and a message for users
+	return apperr.Wrap(&StepError{Step: "prepare", Err: ErrNotReady},
+		apperr.CodeTimeout, "the service is still starting, try again")
+}
+
+func doSomething() error {
+	if err := prepare(); err != nil {
+		// %w keeps the original error in the chain
+		return fmt.Errorf("unable to do something: %w", err)
+	}
+	return nil
+}
+
+func main() {
+	err := doSomething()
+	if err == nil {
+		fmt.Println("Success")
+		return
+	}
+	fmt.Println("Error:", err)
+
+	// errors.Is matches a sentinel anywhere in the chain
+	if errors.Is(err, ErrNotReady) {
+		fmt.Println("Is ErrNotReady: true")
+	}
+
+	// errors.As finds the first error of a given type
+	var stepErr *StepError
+	if errors.As(err, &stepErr) {
+		fmt.Println("Failed step:", stepErr.Step)
+	}
+
+	// The apperr code and user message survive the %w wrapping
+	fmt.Println("Code:", apperr.CodeOf(err))
+	fmt.Println("User message:", apperr.MessageOf(err))
+
+	// apperr.Chain lists every error in the chain; FormatChain prints it
+	fmt.Println("Chain length:", len(apperr.Chain(err)))
+	fmt.Println(apperr.FormatChain(err))
+}
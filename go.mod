@@ -0,0 +1,5 @@
+module github.com/mUsman2003/golang_practice
+
+go 1.22
+
+require golang.org/x/sync v0.11.0
@@ -0,0 +1,95 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+)
+
+// Code is a machine-readable error code
+type Code string
+
+const (
+	CodeInternal     Code = "internal"
+	CodeInvalid      Code = "invalid"
+	CodeNotFound     Code = "not_found"
+	CodeTimeout      Code = "timeout"
+	CodeTooLarge     Code = "too_large"
+	CodeUnauthorized Code = "unauthorized"
+	CodeForbidden    Code = "forbidden"
+	CodeRateLimited  Code = "rate_limited"
+)
+
+// Sentinels shared by all modules. Wrap them with %w or Wrap so callers
+// can branch on errors.Is.
+var (
+	ErrNotFound     = New(CodeNotFound, "not found")
+	ErrTimeout      = New(CodeTimeout, "timed out")
+	ErrTooLarge     = New(CodeTooLarge, "too large")
+	ErrUnauthorized = New(CodeUnauthorized, "unauthorized")
+	ErrForbidden    = New(CodeForbidden, "forbidden")
+	ErrRateLimited  = New(CodeRateLimited, "rate limited")
+)
+
+// Error carries a code, a message safe to show to users, and the cause
+type Error struct {
+	Code    Code
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New creates an error with the given code and user message
+func New(code Code, message string) error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap attaches a code and user message to an existing error
+func Wrap(err error, code Code, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+// CodeOf returns the code of the first Error in the chain, CodeInvalid for
+// ValidationErrors, CodeTimeout for context.DeadlineExceeded, or CodeInternal
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	var v ValidationErrors
+	if errors.As(err, &v) {
+		return CodeInvalid
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return CodeTimeout
+	}
+	return CodeInternal
+}
+
+// MessageOf returns the user message of the first Error in the chain
+func MessageOf(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Message
+	}
+	var v ValidationErrors
+	if errors.As(err, &v) {
+		return "validation failed"
+	}
+	return ""
+}
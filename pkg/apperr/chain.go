@@ -0,0 +1,40 @@
+package apperr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Chain returns err followed by every error it wraps, depth first
+func Chain(err error) []error {
+	var chain []error
+	walk(err, &chain)
+	return chain
+}
+
+func walk(err error, chain *[]error) {
+	if err == nil {
+		return
+	}
+	*chain = append(*chain, err)
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		walk(e.Unwrap(), chain)
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			walk(inner, chain)
+		}
+	}
+}
+
+// FormatChain renders the chain one error per line, with its type, for logs
+func FormatChain(err error) string {
+	var b strings.Builder
+	for i, e := range Chain(err) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%d: %s (%T)", i, e.Error(), e)
+	}
+	return b.String()
+}
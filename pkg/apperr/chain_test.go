@@ -0,0 +1,60 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	root := errors.New("root")
+	a := errors.New("a")
+	b := errors.New("b")
+	wrapped := fmt.Errorf("top: %w", root)
+	joined := errors.Join(a, fmt.Errorf("mid: %w", b))
+
+	tests := []struct {
+		name string
+		err  error
+		want []error
+	}{
+		{"nil", nil, nil},
+		{"single", root, []error{root}},
+		{"%w chain", fmt.Errorf("outer: %w", wrapped), []error{nil, wrapped, root}},
+		// Unwrap() []error trees are walked depth first, left to right
+		{"errors.Join tree", fmt.Errorf("batch: %w", joined), []error{nil, joined, a, nil, b}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Chain(tt.err)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Chain = %v, want %d errors", got, len(tt.want))
+			}
+			if len(got) > 0 && got[0] != tt.err {
+				t.Errorf("chain[0] = %v, want the error itself", got[0])
+			}
+			for i, want := range tt.want {
+				// nil marks an intermediate wrapper created inline above
+				if want != nil && got[i] != want {
+					t.Errorf("chain[%d] = %v, want %v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatChain(t *testing.T) {
+	if got := FormatChain(nil); got != "" {
+		t.Errorf("FormatChain(nil) = %q, want empty", got)
+	}
+	got := FormatChain(fmt.Errorf("load: %w", ErrNotFound))
+	want := "0: load: not found (*fmt.wrapError)\n1: not found (*apperr.Error)"
+	if got != want {
+		t.Errorf("FormatChain = %q, want %q", got, want)
+	}
+	got = FormatChain(errors.Join(ErrNotFound, ErrTimeout))
+	if !strings.HasSuffix(got, "\n1: not found (*apperr.Error)\n2: timed out (*apperr.Error)") {
+		t.Errorf("FormatChain of a joined error = %q", got)
+	}
+}
@@ -0,0 +1,36 @@
+package apperr_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mUsman2003/golang_practice/pkg/apperr"
+)
+
+func Example() {
+	err := fmt.Errorf("load item 42: %w", apperr.ErrNotFound)
+
+	fmt.Println(errors.Is(err, apperr.ErrNotFound))
+	fmt.Println(apperr.CodeOf(err))
+	fmt.Println(apperr.HTTPStatus(err))
+	// Output:
+	// true
+	// not_found
+	// 404
+}
+
+func ExampleMultiError() {
+	var m apperr.MultiError
+	for _, name := range []string{"a", "b"} {
+		m.Add(fmt.Errorf("fetch %s: %w", name, apperr.ErrTimeout))
+	}
+
+	err := m.Err()
+	fmt.Println(errors.Is(err, apperr.ErrTimeout))
+	fmt.Println(err)
+	// Output:
+	// true
+	// 2 errors occurred:
+	// 	* fetch a: timed out
+	// 	* fetch b: timed out
+}
@@ -0,0 +1,87 @@
+package apperr
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Hook receives every error passed to Report
+type Hook interface {
+	Handle(ctx context.Context, err error)
+}
+
+// HookFunc adapts a function to Hook
+type HookFunc func(ctx context.Context, err error)
+
+func (f HookFunc) Handle(ctx context.Context, err error) { f(ctx, err) }
+
+// Hooks fans an error out to several hooks in order
+type Hooks []Hook
+
+func (hs Hooks) Handle(ctx context.Context, err error) {
+	for _, h := range hs {
+		h.Handle(ctx, err)
+	}
+}
+
+var hook atomic.Pointer[Hook]
+
+// SetHook installs the global hook; nil, including a typed nil such as
+// (*Counter)(nil), removes it
+func SetHook(h Hook) {
+	if isNil(h) {
+		hook.Store(nil)
+		return
+	}
+	hook.Store(&h)
+}
+
+func isNil(h Hook) bool {
+	if h == nil {
+		return true
+	}
+	v := reflect.ValueOf(h)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// Report passes a non-nil err to the global hook, if one is set
+func Report(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	if h := hook.Load(); h != nil {
+		(*h).Handle(ctx, err)
+	}
+}
+
+// Counter counts reported errors by code
+type Counter struct {
+	mu     sync.Mutex
+	counts map[Code]int
+}
+
+func (c *Counter) Handle(ctx context.Context, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[Code]int)
+	}
+	c.counts[CodeOf(err)]++
+}
+
+// Counts returns a snapshot of the counts
+func (c *Counter) Counts() map[Code]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[Code]int, len(c.counts))
+	for code, n := range c.counts {
+		out[code] = n
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package apperr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSetHookTypedNil(t *testing.T) {
+	defer SetHook(nil)
+	SetHook((*Counter)(nil))
+	Report(context.Background(), ErrNotFound)
+}
+
+func TestReporterSendsSentryEvents(t *testing.T) {
+	t.Run("no prefix", func(t *testing.T) { testReporterSends(t, "", "/api/42/store/") })
+	t.Run("path prefix", func(t *testing.T) { testReporterSends(t, "/prefix", "/prefix/api/42/store/") })
+}
+
+func testReporterSends(t *testing.T, prefix, wantPath string) {
+	var (
+		mu     sync.Mutex
+		events []sentryEvent
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != wantPath {
+			t.Errorf("path = %q, want %q", r.URL.Path, wantPath)
+		}
+		if auth := r.Header.Get("X-Sentry-Auth"); !strings.Contains(auth, "sentry_key=public") {
+			t.Errorf("X-Sentry-Auth = %q", auth)
+		}
+		var e sentryEvent
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	dsn := strings.Replace(srv.URL, "://", "://public@", 1) + prefix + "/42"
+	// SampleRate is left at zero, which must mean send everything
+	r, err := NewReporter(dsn, ReporterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		r.Handle(context.Background(), fmt.Errorf("fetch %d: %w", i, ErrTimeout))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := r.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 5 {
+		t.Fatalf("got %d events, want 5", len(events))
+	}
+	e := events[0]
+	if len(e.EventID) != 32 || e.Tags["code"] != string(CodeTimeout) {
+		t.Errorf("event = %+v", e)
+	}
+	if vals := e.Exception.Values; len(vals) != 2 || vals[0].Value != "timed out" {
+		t.Errorf("exception values = %+v, want root cause first", vals)
+	}
+
+	// Reports after Close are dropped rather than panicking
+	r.Handle(context.Background(), errors.New("late"))
+}
+
+func TestNewReporterBadDSN(t *testing.T) {
+	for _, dsn := range []string{"", "https://sentry.io/1", "https://key@sentry.io/", "https://key@sentry.io", "https://key@sentry.io/prefix/"} {
+		if _, err := NewReporter(dsn, ReporterOptions{}); err == nil {
+			t.Errorf("NewReporter(%q) succeeded", dsn)
+		}
+	}
+}
+
+func TestReporterHandleDoesNotBlockDuringFlush(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+
+	dsn := strings.Replace(srv.URL, "://", "://public@", 1) + "/1"
+	r, err := NewReporter(dsn, ReporterOptions{QueueSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// One report is stuck in send and one fills the queue
+	r.Handle(context.Background(), ErrTimeout)
+	r.Handle(context.Background(), ErrTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	flushed := make(chan error, 2)
+	go func() { flushed <- r.Flush(ctx) }()
+	go func() { flushed <- r.Close(ctx) }()
+	time.Sleep(20 * time.Millisecond)
+
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		r.Handle(context.Background(), ErrTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Handle blocked for %v while Flush and Close waited", elapsed)
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-flushed; err != nil {
+			t.Errorf("flush/close: %v", err)
+		}
+	}
+}
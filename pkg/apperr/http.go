@@ -0,0 +1,84 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var codeStatus = map[Code]int{
+	CodeInvalid:      http.StatusBadRequest,
+	CodeUnauthorized: http.StatusUnauthorized,
+	CodeForbidden:    http.StatusForbidden,
+	CodeNotFound:     http.StatusNotFound,
+	CodeTooLarge:     http.StatusRequestEntityTooLarge,
+	CodeRateLimited:  http.StatusTooManyRequests,
+	CodeTimeout:      http.StatusGatewayTimeout,
+	CodeInternal:     http.StatusInternalServerError,
+}
+
+var statusSentinel = map[int]error{
+	http.StatusUnauthorized:          ErrUnauthorized,
+	http.StatusForbidden:             ErrForbidden,
+	http.StatusNotFound:              ErrNotFound,
+	http.StatusRequestTimeout:        ErrTimeout,
+	http.StatusRequestEntityTooLarge: ErrTooLarge,
+	http.StatusTooManyRequests:       ErrRateLimited,
+	http.StatusGatewayTimeout:        ErrTimeout,
+}
+
+// HTTPStatus returns the status code a server should reply with for err
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	if status, ok := codeStatus[CodeOf(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// FromHTTPStatus turns a response status into an error, or nil for 1xx-3xx.
+// The result matches both the *StatusError and, where one fits, a sentinel.
+func FromHTTPStatus(status int) error {
+	if status < 400 {
+		return nil
+	}
+	statusErr := &StatusError{StatusCode: status}
+	if sentinel, ok := statusSentinel[status]; ok {
+		return fmt.Errorf("%w: %w", sentinel, statusErr)
+	}
+	return statusErr
+}
+
+// Problem is an RFC 7807 problem+json body
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     Code   `json:"code,omitempty"`
+
+	Errors ValidationErrors `json:"errors,omitempty"`
+}
+
+// ProblemFor builds the response body for err. Only the user message is
+// exposed as detail, never the wrapped cause. Callers set Instance, usually
+// to the request path.
+func ProblemFor(err error) Problem {
+	status := HTTPStatus(err)
+	p := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: MessageOf(err),
+		Code:   CodeOf(err),
+	}
+	errors.As(err, &p.Errors)
+	return p
+}
@@ -0,0 +1,59 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, http.StatusOK},
+		{errors.New("boom"), http.StatusInternalServerError},
+		{ErrUnauthorized, http.StatusUnauthorized},
+		{fmt.Errorf("item: %w", ErrForbidden), http.StatusForbidden},
+		{ErrTimeout, http.StatusGatewayTimeout},
+		{context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{fmt.Errorf("query: %w", context.DeadlineExceeded), http.StatusGatewayTimeout},
+		{&StatusError{StatusCode: http.StatusTeapot}, http.StatusTeapot},
+		{ValidationErrors{{Field: "name", Message: "required"}}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if got := HTTPStatus(tt.err); got != tt.want {
+			t.Errorf("HTTPStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestFromHTTPStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusGatewayTimeout, ErrTimeout},
+	}
+	for _, tt := range tests {
+		err := FromHTTPStatus(tt.status)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("FromHTTPStatus(%d) = %v, want it to match %v", tt.status, err, tt.want)
+		}
+		if HTTPStatus(err) != tt.status {
+			t.Errorf("HTTPStatus(FromHTTPStatus(%d)) = %d", tt.status, HTTPStatus(err))
+		}
+	}
+	if errors.Is(FromHTTPStatus(http.StatusForbidden), ErrUnauthorized) {
+		t.Error("403 matches ErrUnauthorized")
+	}
+	if err := FromHTTPStatus(http.StatusNoContent); err != nil {
+		t.Errorf("FromHTTPStatus(204) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,71 @@
+package apperr
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MultiError collects errors from loops or goroutines. It is safe for
+// concurrent use; the zero value is ready to use.
+type MultiError struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// Add records err, ignoring nil
+func (m *MultiError) Add(err error) {
+	if err == nil {
+		return
+	}
+	m.mu.Lock()
+	m.errs = append(m.errs, err)
+	m.mu.Unlock()
+}
+
+// Errors returns a copy of the collected errors
+func (m *MultiError) Errors() []error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]error(nil), m.errs...)
+}
+
+// Len returns the number of collected errors
+func (m *MultiError) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.errs)
+}
+
+// Err returns nil when nothing was collected, otherwise a snapshot of the
+// errors so far that later calls to Add do not change
+func (m *MultiError) Err() error {
+	errs := m.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	return &MultiError{errs: errs}
+}
+
+func (m *MultiError) Error() string {
+	errs := m.Errors()
+	switch len(errs) {
+	case 0:
+		// Only reachable by using a MultiError directly instead of via Err
+		return "no errors"
+	case 1:
+		return errs[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d errors occurred:", len(errs))
+	for _, err := range errs {
+		b.WriteString("\n\t* ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap lets errors.Is and errors.As match any member
+func (m *MultiError) Unwrap() []error {
+	return m.Errors()
+}
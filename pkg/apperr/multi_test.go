@@ -0,0 +1,43 @@
+package apperr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMultiErrorErrIsSnapshot(t *testing.T) {
+	var m MultiError
+	if m.Err() != nil {
+		t.Fatal("empty MultiError returned a non-nil error")
+	}
+
+	m.Add(ErrNotFound)
+	err := m.Err()
+	before := err.Error()
+
+	m.Add(ErrTimeout)
+	if got := err.Error(); got != before {
+		t.Errorf("returned error changed after Add: %q, was %q", got, before)
+	}
+	if errors.Is(err, ErrTimeout) {
+		t.Error("returned error matches an error added later")
+	}
+	if !errors.Is(m.Err(), ErrTimeout) {
+		t.Error("new snapshot is missing the later error")
+	}
+}
+
+func TestMultiErrorText(t *testing.T) {
+	var m MultiError
+	if got := m.Error(); got != "no errors" {
+		t.Errorf("zero value Error() = %q, want %q", got, "no errors")
+	}
+	m.Add(ErrNotFound)
+	if got := m.Error(); got != "not found" {
+		t.Errorf("one error Error() = %q", got)
+	}
+	m.Add(ErrTimeout)
+	if got, want := m.Error(), "2 errors occurred:\n\t* not found\n\t* timed out"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+// Retryable is implemented by errors that know whether retrying can help
+type Retryable interface {
+	Retryable() bool
+}
+
+type classified struct {
+	err       error
+	retryable bool
+}
+
+func (c *classified) Error() string   { return c.err.Error() }
+func (c *classified) Unwrap() error   { return c.err }
+func (c *classified) Retryable() bool { return c.retryable }
+
+// MarkPermanent makes IsRetryable report false for err
+func MarkPermanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classified{err: err, retryable: false}
+}
+
+// MarkRetryable makes IsRetryable report true for err
+func MarkRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classified{err: err, retryable: true}
+}
+
+// StatusError reports a non-success HTTP response
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return "http status " + strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode)
+}
+
+// IsRetryable reports whether the operation that produced err may succeed
+// if tried again. An explicit mark wins over the built-in rules.
+//
+// Context errors are permanent: once a context is canceled or past its
+// deadline, every retry under it fails the same way. A retry loop that
+// gives each attempt its own deadline should mark those attempt errors
+// with MarkRetryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var r Retryable
+	if errors.As(err, &r) {
+		return r.Retryable()
+	}
+
+	// The caller gave up, so trying again is pointless. This is checked
+	// before net.Error because context.DeadlineExceeded reports Timeout.
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch code := statusErr.StatusCode; {
+		case code == http.StatusRequestTimeout, code == http.StatusTooManyRequests, code >= 500:
+			return true
+		}
+		return false
+	}
+
+	code := CodeOf(err)
+	return code == CodeTimeout || code == CodeRateLimited
+}
@@ -0,0 +1,59 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+)
+
+func TestIsRetryable(t *testing.T) {
+	netTimeout := &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("bad input"), false},
+		{"net timeout", netTimeout, true},
+		{"wrapped net timeout", fmt.Errorf("fetch: %w", netTimeout), true},
+		{"dns not found", &net.DNSError{Err: "no such host", Name: "x.invalid", IsNotFound: true}, false},
+		{"dns temporary", &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}, true},
+		{"dns timeout", &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}, true},
+		{"408", FromHTTPStatus(408), true},
+		{"429", &StatusError{StatusCode: 429}, true},
+		{"500", &StatusError{StatusCode: 500}, true},
+		{"503", FromHTTPStatus(503), true},
+		{"404", FromHTTPStatus(404), false},
+		{"400", &StatusError{StatusCode: 400}, false},
+		{"context canceled", context.Canceled, false},
+		{"wrapped context canceled", fmt.Errorf("fetch: %w", context.Canceled), false},
+		{"deadline exceeded", context.DeadlineExceeded, false},
+		{"wrapped deadline exceeded", fmt.Errorf("fetch: %w", context.DeadlineExceeded), false},
+		{"ErrTimeout", ErrTimeout, true},
+		{"ErrRateLimited", fmt.Errorf("api: %w", ErrRateLimited), true},
+		{"ErrNotFound", ErrNotFound, false},
+		{"permanent 503", MarkPermanent(&StatusError{StatusCode: 503}), false},
+		{"retryable deadline", MarkRetryable(context.DeadlineExceeded), true},
+		{"retryable plain", MarkRetryable(errors.New("flaky")), true},
+		{"outer mark wins", MarkPermanent(MarkRetryable(netTimeout)), false},
+		{"outer retryable wins", MarkRetryable(MarkPermanent(netTimeout)), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkNil(t *testing.T) {
+	if MarkPermanent(nil) != nil || MarkRetryable(nil) != nil {
+		t.Error("marking nil should return nil")
+	}
+}
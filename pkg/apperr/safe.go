@@ -0,0 +1,52 @@
+package apperr
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// PanicError is returned by SafeCall when fn panics. Like errors from
+// Errorf, %+v prints it with the stack of the panic and Frames returns it.
+type PanicError struct {
+	Value any
+	stack
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value when it was itself an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+func (e *PanicError) Format(s fmt.State, verb rune) {
+	e.stack.format(s, verb, e.Error())
+}
+
+// SafeCall runs fn and turns a panic into a *PanicError with the stack trace
+func SafeCall(fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Value: v, stack: panicStack(callers(1))}
+		}
+	}()
+	return fn()
+}
+
+// panicStack drops the leading runtime frames (gopanic, and sigpanic for
+// faults such as a nil dereference) so frame 0 is the function that panicked
+func panicStack(s stack) stack {
+	for len(s) > 0 {
+		// Callers returns return addresses; step back into the call
+		fn := runtime.FuncForPC(s[0] - 1)
+		if fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+			break
+		}
+		s = s[1:]
+	}
+	return s
+}
@@ -0,0 +1,259 @@
+package apperr
+
+import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReporterOptions tunes a Reporter. Zero values pick the defaults.
+type ReporterOptions struct {
+	Client *http.Client
+
+	// SampleRate is the fraction of errors sent, from 0 to 1; 0 means 1.
+	// To send nothing, do not install a Reporter.
+	SampleRate float64
+	// MaxPerMinute caps sent reports; 0 means no cap
+	MaxPerMinute int
+	// QueueSize bounds reports waiting to be sent; further reports are
+	// dropped until the queue drains. Defaults to 100.
+	QueueSize int
+	// Timeout limits each send. Defaults to 5 seconds.
+	Timeout time.Duration
+}
+
+// Reporter sends sampled errors to a Sentry project using the store API.
+// Reports are queued and sent by one background goroutine; call Flush or
+// Close before the program exits so queued reports are not lost.
+type Reporter struct {
+	endpoint string
+	auth     string
+	opts     ReporterOptions
+
+	queue     chan report
+	closing   chan struct{} // closed by Close; the queue itself never is
+	closeOnce sync.Once
+	done      chan struct{} // closed when the send loop exits
+
+	limitMu sync.Mutex
+	window  time.Time
+	sent    int
+}
+
+// report is either an event to send or, when flushed is set, a marker the
+// worker closes once everything queued before it has been sent
+type report struct {
+	event   *sentryEvent
+	flushed chan struct{}
+}
+
+// NewReporter parses a Sentry DSN of the form
+// https://<public_key>@<host>[/<path>]/<project_id> and starts the send loop.
+func NewReporter(dsn string, opts ReporterOptions) (*Reporter, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sentry dsn: %w", err)
+	}
+	key := u.User.Username()
+	// The project ID is the last path segment; anything before it is a
+	// prefix that goes in front of /api/
+	i := strings.LastIndex(u.Path, "/")
+	if u.Scheme == "" || u.Host == "" || key == "" || i < 0 || i == len(u.Path)-1 {
+		return nil, fmt.Errorf("sentry dsn %q: want scheme://key@host[/path]/project", dsn)
+	}
+	prefix, project := u.Path[:i], u.Path[i+1:]
+
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.SampleRate == 0 {
+		opts.SampleRate = 1
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 100
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	r := &Reporter{
+		endpoint: u.Scheme + "://" + u.Host + prefix + "/api/" + project + "/store/",
+		auth:     "Sentry sentry_version=7, sentry_client=golang_practice/1.0, sentry_key=" + key,
+		opts:     opts,
+		queue:    make(chan report, opts.QueueSize),
+		closing:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go r.loop()
+	return r, nil
+}
+
+// sentryEvent is the subset of the Sentry event payload that is filled in
+type sentryEvent struct {
+	EventID   string            `json:"event_id"`
+	Timestamp string            `json:"timestamp"`
+	Level     string            `json:"level"`
+	Platform  string            `json:"platform"`
+	Message   string            `json:"message"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	Exception struct {
+		Values []sentryException `json:"values"`
+	} `json:"exception"`
+}
+
+type sentryException struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func newEvent(err error) *sentryEvent {
+	var id [16]byte
+	crand.Read(id[:])
+
+	event := &sentryEvent{
+		EventID:   hex.EncodeToString(id[:]),
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Level:     "error",
+		Platform:  "go",
+		Message:   err.Error(),
+		Tags:      map[string]string{"code": string(CodeOf(err))},
+	}
+	// Sentry lists chained exceptions with the root cause first
+	chain := Chain(err)
+	for i := len(chain) - 1; i >= 0; i-- {
+		event.Exception.Values = append(event.Exception.Values, sentryException{
+			Type:  fmt.Sprintf("%T", chain[i]),
+			Value: chain[i].Error(),
+		})
+	}
+	return event
+}
+
+// Handle queues err for sending, dropping it when sampled out, over the
+// rate limit, the queue is full or the Reporter is closed
+func (r *Reporter) Handle(ctx context.Context, err error) {
+	if rand.Float64() >= r.opts.SampleRate || !r.allow(time.Now()) {
+		return
+	}
+	select {
+	case <-r.closing:
+		return
+	default:
+	}
+	select {
+	case r.queue <- report{event: newEvent(err)}:
+	default:
+	}
+}
+
+// Flush waits until every report queued before the call has been sent.
+// It only blocks its own caller; Handle keeps dropping reports while the
+// queue is full.
+func (r *Reporter) Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+	select {
+	case r.queue <- report{flushed: flushed}:
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes queued reports and stops the send loop. Reports handled
+// after Close are dropped.
+func (r *Reporter) Close(ctx context.Context) error {
+	flushErr := r.Flush(ctx)
+	r.closeOnce.Do(func() { close(r.closing) })
+
+	select {
+	case <-r.done:
+		return flushErr
+	case <-ctx.Done():
+		return errors.Join(flushErr, ctx.Err())
+	}
+}
+
+func (r *Reporter) loop() {
+	defer close(r.done)
+	for {
+		select {
+		case rep := <-r.queue:
+			r.process(rep)
+		case <-r.closing:
+			// Send whatever was queued before Close, then stop
+			for {
+				select {
+				case rep := <-r.queue:
+					r.process(rep)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *Reporter) process(rep report) {
+	if rep.flushed != nil {
+		close(rep.flushed)
+		return
+	}
+	r.send(rep.event)
+}
+
+func (r *Reporter) send(event *sentryEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), r.opts.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", r.auth)
+	resp, err := r.opts.Client.Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+func (r *Reporter) allow(now time.Time) bool {
+	if r.opts.MaxPerMinute <= 0 {
+		return true
+	}
+	r.limitMu.Lock()
+	defer r.limitMu.Unlock()
+	if now.Sub(r.window) >= time.Minute {
+		r.window = now
+		r.sent = 0
+	}
+	if r.sent >= r.opts.MaxPerMinute {
+		return false
+	}
+	r.sent++
+	return true
+}
@@ -0,0 +1,96 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+)
+
+// stackMode controls when frames are printed, from the APPERR_STACK
+// environment variable:
+//
+//	off   never print frames
+//	full  print frames for %v as well as %+v
+//	other print frames only for %+v
+var stackMode = os.Getenv("APPERR_STACK")
+
+// stack is a recorded call stack shared by stackError and PanicError
+type stack []uintptr
+
+// callers records the stack of the function skip levels above its caller
+func callers(skip int) stack {
+	pcs := make([]uintptr, 32)
+	// Skip runtime.Callers and callers itself
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
+
+// Frames returns the recorded call stack
+func (s stack) Frames() []runtime.Frame {
+	var frames []runtime.Frame
+	iter := runtime.CallersFrames(s)
+	for {
+		frame, more := iter.Next()
+		frames = append(frames, frame)
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// format writes msg and, for %v and %+v as allowed by stackMode, one frame
+// per line. Other verbs fall back to the message.
+func (s stack) format(st fmt.State, verb rune, msg string) {
+	switch verb {
+	case 'v':
+		io.WriteString(st, msg)
+		if stackMode != "off" && (st.Flag('+') || stackMode == "full") {
+			for _, f := range s.Frames() {
+				fmt.Fprintf(st, "\n\t%s\n\t\t%s:%d", f.Function, f.File, f.Line)
+			}
+		}
+	case 'q':
+		fmt.Fprintf(st, "%q", msg)
+	default:
+		io.WriteString(st, msg)
+	}
+}
+
+type stackError struct {
+	err error
+	stack
+}
+
+// WithStack records the caller's stack on err
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &stackError{err: err, stack: callers(1)}
+}
+
+// Errorf is fmt.Errorf that also records the caller's stack
+func Errorf(format string, args ...any) error {
+	return &stackError{err: fmt.Errorf(format, args...), stack: callers(1)}
+}
+
+func (e *stackError) Error() string { return e.err.Error() }
+func (e *stackError) Unwrap() error { return e.err }
+
+// Format supports %s, %q, %v and %+v, the last adding one frame per line
+func (e *stackError) Format(s fmt.State, verb rune) {
+	e.stack.format(s, verb, e.err.Error())
+}
+
+// StackOf returns the frames of the first stack-carrying error in the
+// chain, including a *PanicError from SafeCall
+func StackOf(err error) []runtime.Frame {
+	var st interface{ Frames() []runtime.Frame }
+	if errors.As(err, &st) {
+		return st.Frames()
+	}
+	return nil
+}
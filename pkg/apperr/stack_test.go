@@ -0,0 +1,104 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func panics() error {
+	panic("boom")
+}
+
+func derefsNil() error {
+	var p *Error
+	return p.Err
+}
+
+// setStackMode overrides APPERR_STACK for one test, ignoring whatever the
+// environment set at init
+func setStackMode(t *testing.T, mode string) {
+	old := stackMode
+	stackMode = mode
+	t.Cleanup(func() { stackMode = old })
+}
+
+func TestSafeCallStack(t *testing.T) {
+	setStackMode(t, "")
+	err := SafeCall(panics)
+
+	var pe *PanicError
+	if !errors.As(err, &pe) || pe.Value != "boom" {
+		t.Fatalf("err = %v, want *PanicError for boom", err)
+	}
+	frames := StackOf(fmt.Errorf("job: %w", err))
+	if len(frames) == 0 {
+		t.Fatal("StackOf returned no frames for a wrapped *PanicError")
+	}
+	if !strings.HasSuffix(frames[0].Function, "apperr.panics") {
+		t.Errorf("frame 0 = %s, want the panicking function", frames[0].Function)
+	}
+	if out := fmt.Sprintf("%+v", err); !strings.Contains(out, "apperr.panics") {
+		t.Errorf("%%+v does not show the panicking function:\n%s", out)
+	}
+}
+
+func TestSafeCallRuntimePanicStack(t *testing.T) {
+	frames := StackOf(SafeCall(derefsNil))
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "apperr.derefsNil") {
+		t.Fatalf("frames = %v, want frame 0 to be derefsNil", frames)
+	}
+}
+
+func TestStackErrorFormat(t *testing.T) {
+	setStackMode(t, "")
+	err := Errorf("load %d", 7)
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "load 7"},
+		{"%s", "load 7"},
+		{"%q", `"load 7"`},
+		{"%d", "load 7"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, err); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+	if out := fmt.Sprintf("%+v", err); !strings.Contains(out, "TestStackErrorFormat") {
+		t.Errorf("%%+v does not show the caller:\n%s", out)
+	}
+}
+
+func TestStackModes(t *testing.T) {
+	err := Errorf("load")
+	panicErr := SafeCall(panics)
+
+	tests := []struct {
+		mode   string
+		format string
+		frames bool
+	}{
+		{"", "%v", false},
+		{"", "%+v", true},
+		{"off", "%v", false},
+		{"off", "%+v", false},
+		{"full", "%v", true},
+		{"full", "%+v", true},
+		{"full", "%s", false},
+	}
+	for _, tt := range tests {
+		setStackMode(t, tt.mode)
+		for _, e := range []error{err, panicErr} {
+			out := fmt.Sprintf(tt.format, e)
+			if got := strings.Contains(out, "\n\t"); got != tt.frames {
+				t.Errorf("mode %q, %s of %T: frames printed = %v, want %v\n%s",
+					tt.mode, tt.format, e, got, tt.frames, out)
+			}
+		}
+	}
+}
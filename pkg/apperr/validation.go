@@ -0,0 +1,43 @@
+package apperr
+
+import (
+	"strings"
+)
+
+// FieldError is one invalid field and why
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors accumulates field errors in the order they were found.
+// It marshals to a JSON array of {field, message} objects.
+type ValidationErrors []FieldError
+
+// Add records a message for field
+func (v *ValidationErrors) Add(field, message string) {
+	*v = append(*v, FieldError{Field: field, Message: message})
+}
+
+// Check records message for field when ok is false
+func (v *ValidationErrors) Check(ok bool, field, message string) {
+	if !ok {
+		v.Add(field, message)
+	}
+}
+
+// Err returns nil when there are no field errors, otherwise v
+func (v ValidationErrors) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+func (v ValidationErrors) Error() string {
+	parts := make([]string, len(v))
+	for i, fe := range v {
+		parts[i] = fe.Field + ": " + fe.Message
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
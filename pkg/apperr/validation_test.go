@@ -0,0 +1,63 @@
+package apperr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestValidationErrorsJSON(t *testing.T) {
+	var v ValidationErrors
+	v.Add("name", "required")
+	v.Check(false, "age", "must be at least 0")
+	v.Check(true, "email", "never recorded")
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"field":"name","message":"required"},{"field":"age","message":"must be at least 0"}]`
+	if string(b) != want {
+		t.Errorf("json = %s, want %s", b, want)
+	}
+}
+
+func TestValidationErrorsErr(t *testing.T) {
+	var v ValidationErrors
+	if err := v.Err(); err != nil {
+		t.Errorf("empty Err() = %v, want nil", err)
+	}
+	v.Add("name", "required")
+	if err := v.Err(); err == nil || err.Error() != "validation failed: name: required" {
+		t.Errorf("Err() = %v", err)
+	}
+}
+
+func TestProblemForValidationErrors(t *testing.T) {
+	var v ValidationErrors
+	v.Add("name", "required")
+
+	p := ProblemFor(fmt.Errorf("decode item: %w", v.Err()))
+	if p.Status != http.StatusBadRequest || p.Code != CodeInvalid {
+		t.Errorf("problem = %+v, want 400 invalid", p)
+	}
+	if len(p.Errors) != 1 || p.Errors[0] != (FieldError{Field: "name", Message: "required"}) {
+		t.Errorf("problem errors = %v", p.Errors)
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		Errors []FieldError `json:"errors"`
+	}
+	if err := json.Unmarshal(b, &body); err != nil || len(body.Errors) != 1 {
+		t.Errorf("problem json = %s", b)
+	}
+
+	if p := ProblemFor(ErrNotFound); p.Errors != nil {
+		t.Errorf("non-validation problem has errors: %v", p.Errors)
+	}
+}
@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Options selects the output format and minimum level
+type Options struct {
+	Format string // "text" or "json"
+	Level  string // "debug", "info", "warn" or "error"
+}
+
+// New builds a logger writing to w. Attributes stored with With are
+// added to every record logged with a context.
+func New(w io.Writer, opts Options) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(orDefault(opts.Level, "info"))); err != nil {
+		return nil, fmt.Errorf("log level %q: %w", opts.Level, err)
+	}
+	handlerOpts := &slog.HandlerOptions{Level: level}
+
+	var h slog.Handler
+	switch strings.ToLower(orDefault(opts.Format, "text")) {
+	case "text":
+		h = slog.NewTextHandler(w, handlerOpts)
+	case "json":
+		h = slog.NewJSONHandler(w, handlerOpts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", opts.Format)
+	}
+	return slog.New(&contextHandler{base: h, h: h}), nil
+}
+
+// Module returns a child logger tagged with the module name
+func Module(l *slog.Logger, name string) *slog.Logger {
+	return l.With("module", name)
+}
+
+type ctxKey struct{}
+
+// ctxAttrs is the context value stored by With. It also caches, per logger,
+// the handler rebuilt with these attributes, so a logger with open groups
+// pays for the rebuild once per context rather than once per record.
+type ctxAttrs struct {
+	attrs []slog.Attr
+
+	mu    sync.Mutex
+	built map[*contextHandler]slog.Handler
+}
+
+func (c *ctxAttrs) handlerFor(h *contextHandler) slog.Handler {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if inner, ok := c.built[h]; ok {
+		return inner
+	}
+	inner := h.base.WithAttrs(c.attrs)
+	for _, op := range h.ops {
+		inner = op(inner)
+	}
+	if c.built == nil {
+		c.built = make(map[*contextHandler]slog.Handler)
+	}
+	c.built[h] = inner
+	return inner
+}
+
+// With returns a context carrying extra log attributes, such as a request
+// ID, URL or attempt number. They are logged at the top level, outside any
+// group opened with WithGroup.
+func With(ctx context.Context, args ...any) context.Context {
+	var parent []slog.Attr
+	if c, ok := ctx.Value(ctxKey{}).(*ctxAttrs); ok {
+		parent = c.attrs
+	}
+	// Concat allocates, so sibling contexts never share a backing array
+	attrs := slices.Concat(parent, argsToAttrs(args))
+	return context.WithValue(ctx, ctxKey{}, &ctxAttrs{attrs: attrs})
+}
+
+func argsToAttrs(args []any) []slog.Attr {
+	var r slog.Record
+	r.Add(args...)
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return attrs
+}
+
+// contextHandler adds the attributes stored by With to each record. It
+// remembers the WithAttrs and WithGroup calls made on it so that, once a
+// group is open, the context attributes can be attached before it applies.
+type contextHandler struct {
+	base    slog.Handler                      // handler passed to New
+	h       slog.Handler                      // base with ops applied
+	ops     []func(slog.Handler) slog.Handler // WithAttrs/WithGroup calls in order
+	grouped bool                              // whether ops opened a group
+}
+
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.h.Enabled(ctx, level)
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	c, _ := ctx.Value(ctxKey{}).(*ctxAttrs)
+	if c == nil || len(c.attrs) == 0 {
+		return h.h.Handle(ctx, r)
+	}
+	if !h.grouped {
+		// With no group open, record attributes land at the top level
+		r = r.Clone()
+		r.AddAttrs(c.attrs...)
+		return h.h.Handle(ctx, r)
+	}
+	return c.handlerFor(h).Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(false, func(inner slog.Handler) slog.Handler { return inner.WithAttrs(attrs) })
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	// slog ignores WithGroup with an empty name
+	return h.with(name != "", func(inner slog.Handler) slog.Handler { return inner.WithGroup(name) })
+}
+
+func (h *contextHandler) with(group bool, op func(slog.Handler) slog.Handler) slog.Handler {
+	return &contextHandler{
+		base:    h.base,
+		h:       op(h.h),
+		ops:     append(slices.Clip(h.ops), op),
+		grouped: h.grouped || group,
+	}
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
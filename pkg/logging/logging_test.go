@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestContextAttrsOutsideGroups(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(&buf, Options{Format: "json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := With(context.Background(), "request_id", "abc")
+
+	Module(l, "fetch").WithGroup("g").InfoContext(ctx, "done", "url", "http://x")
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["request_id"] != "abc" || got["module"] != "fetch" {
+		t.Errorf("top level = %v", got)
+	}
+	g, _ := got["g"].(map[string]any)
+	if g["url"] != "http://x" || g["request_id"] != nil {
+		t.Errorf("group g = %v", g)
+	}
+}
+
+func TestWithDoesNotShareAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	l, _ := New(&buf, Options{Format: "json"})
+	parent := With(context.Background(), "a", 1)
+	left := With(parent, "b", 2)
+	_ = With(parent, "c", 3)
+
+	l.InfoContext(left, "x")
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["b"] == nil || got["c"] != nil {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestNewRejectsBadOptions(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, Options{Level: "loud"}); err == nil {
+		t.Error("bad level accepted")
+	}
+	if _, err := New(&bytes.Buffer{}, Options{Format: "xml"}); err == nil {
+		t.Error("bad format accepted")
+	}
+}
+
+// countingHandler counts WithAttrs calls made on it and its descendants
+type countingHandler struct {
+	slog.Handler
+	withAttrs *int
+}
+
+func (h countingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	*h.withAttrs++
+	return countingHandler{h.Handler.WithAttrs(attrs), h.withAttrs}
+}
+
+func (h countingHandler) WithGroup(name string) slog.Handler {
+	return countingHandler{h.Handler.WithGroup(name), h.withAttrs}
+}
+
+func TestGroupedHandlerRebuiltOncePerContext(t *testing.T) {
+	var buf bytes.Buffer
+	calls := 0
+	base := countingHandler{slog.NewJSONHandler(&buf, nil), &calls}
+	l := slog.New(&contextHandler{base: base, h: base}).With("module", "fetch").WithGroup("g")
+	ctx := With(context.Background(), "request_id", "abc")
+
+	calls = 0
+	for i := 0; i < 3; i++ {
+		l.InfoContext(ctx, "x")
+	}
+	// One WithAttrs for the context attrs and one replaying With("module")
+	if calls != 2 {
+		t.Errorf("WithAttrs called %d times for 3 records, want 2", calls)
+	}
+	if n := bytes.Count(buf.Bytes(), []byte(`"request_id":"abc"`)); n != 3 {
+		t.Errorf("request_id logged %d times, want 3:\n%s", n, buf.String())
+	}
+}
+
+func TestUngroupedContextAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	l, _ := New(&buf, Options{Format: "json"})
+	l.With("module", "fetch").InfoContext(With(context.Background(), "attempt", 2), "x")
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["attempt"] != float64(2) || got["module"] != "fetch" {
+		t.Errorf("got %v", got)
+	}
+}
@@ -0,0 +1,47 @@
+package parallel
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Func is one unit of work. It should return promptly once ctx is done.
+type Func func(ctx context.Context) error
+
+// Run calls every fn concurrently and returns the first error. The first
+// failure cancels the context passed to the others.
+func Run(ctx context.Context, fns ...Func) error {
+	return RunLimit(ctx, 0, fns...)
+}
+
+// RunLimit is Run with at most limit fns in flight; limit <= 0 means no limit.
+// Once ctx is done no further fns are started, and if any were skipped
+// because the parent ctx ended, its error is returned.
+func RunLimit(ctx context.Context, limit int, fns ...Func) error {
+	g, gctx := errgroup.WithContext(ctx)
+	if limit > 0 {
+		g.SetLimit(limit)
+	}
+
+	skipped := false
+	for _, fn := range fns {
+		if gctx.Err() != nil {
+			skipped = true
+			break
+		}
+		g.Go(func() error {
+			// The context may have ended while Go waited for a free slot
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			return fn(gctx)
+		})
+	}
+
+	err := g.Wait()
+	if err == nil && skipped {
+		err = ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunCancelledParent(t *testing.T) {
+	for _, limit := range []int{0, 1, 100} {
+		for trial := 0; trial < 50; trial++ {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			var started atomic.Int32
+			fns := make([]Func, 11)
+			for i := range fns {
+				fns[i] = func(context.Context) error {
+					started.Add(1)
+					return nil
+				}
+			}
+
+			err := RunLimit(ctx, limit, fns...)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("limit %d: err = %v, want context.Canceled", limit, err)
+			}
+			if n := started.Load(); n != 0 {
+				t.Fatalf("limit %d: %d fns started on a cancelled context", limit, n)
+			}
+		}
+	}
+}
+
+func TestRunFirstErrorCancelsRest(t *testing.T) {
+	errBoom := errors.New("boom")
+	for _, limit := range []int{0, 2} {
+		wait := func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+				return nil
+			}
+		}
+		fail := func(context.Context) error { return errBoom }
+
+		start := time.Now()
+		err := RunLimit(context.Background(), limit, wait, fail, wait, wait)
+		if !errors.Is(err, errBoom) {
+			t.Fatalf("limit %d: err = %v, want %v", limit, err, errBoom)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Fatalf("limit %d: took %v, the failure did not cancel the rest", limit, elapsed)
+		}
+	}
+}
+
+func TestRunLimitRespected(t *testing.T) {
+	const limit = 3
+	var inFlight, peak, ran atomic.Int32
+	fns := make([]Func, 20)
+	for i := range fns {
+		fns[i] = func(context.Context) error {
+			n := inFlight.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+			ran.Add(1)
+			return nil
+		}
+	}
+
+	if err := RunLimit(context.Background(), limit, fns...); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if p := peak.Load(); p > limit {
+		t.Errorf("peak concurrency %d, want at most %d", p, limit)
+	}
+	if n := ran.Load(); n != int32(len(fns)) {
+		t.Errorf("ran %d fns, want %d", n, len(fns))
+	}
+}
+
+func TestRunNoFns(t *testing.T) {
+	if err := Run(context.Background()); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+}
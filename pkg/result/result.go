@@ -0,0 +1,94 @@
+package result
+
+import (
+	"errors"
+)
+
+// ErrNil is stored by Err when it is given a nil error, so the Result still
+// reports a failure
+var ErrNil = errors.New("result: Err called with nil error")
+
+// Result holds either a value or the error that prevented producing it
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok wraps a successful value
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err wraps a failure. A nil err is replaced by ErrNil; use Of to build a
+// Result from an error that may be nil.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		err = ErrNil
+	}
+	return Result[T]{err: err}
+}
+
+// Of builds a Result from the usual (value, error) return pair
+func Of[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(v)
+}
+
+// IsOk reports whether r holds a value
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Get returns the value and error as a normal Go pair
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// Err returns the error, or nil on success
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// OrElse returns the value, or def on failure
+func (r Result[T]) OrElse(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.value
+}
+
+// Must returns the value and panics on failure
+func (r Result[T]) Must() T {
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r.value
+}
+
+// Map applies f to a successful value and passes failures through
+func Map[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(f(r.value))
+}
+
+// Split unpacks a batch of results while keeping each item's position.
+// values[i] holds the value of results[i], or the zero value when it
+// failed, and failures maps the index of every failed item to its error.
+func Split[T any](results []Result[T]) (values []T, failures map[int]error) {
+	values = make([]T, len(results))
+	for i, r := range results {
+		if r.err != nil {
+			if failures == nil {
+				failures = make(map[int]error)
+			}
+			failures[i] = r.err
+			continue
+		}
+		values[i] = r.value
+	}
+	return values, failures
+}
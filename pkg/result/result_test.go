@@ -0,0 +1,76 @@
+package result
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+var errBoom = errors.New("boom")
+
+func TestOf(t *testing.T) {
+	if r := Of(1, nil); !r.IsOk() || r.OrElse(0) != 1 {
+		t.Errorf("Of(1, nil) = %+v", r)
+	}
+	r := Of(1, errBoom)
+	if r.IsOk() || !errors.Is(r.Err(), errBoom) {
+		t.Errorf("Of(1, errBoom) = %+v", r)
+	}
+	if v, err := r.Get(); v != 0 || err != errBoom {
+		t.Errorf("Get() = %v, %v; want zero value and errBoom", v, err)
+	}
+}
+
+func TestErrNil(t *testing.T) {
+	r := Err[int](nil)
+	if r.IsOk() || !errors.Is(r.Err(), ErrNil) {
+		t.Errorf("Err(nil) = %+v, want a failure holding ErrNil", r)
+	}
+}
+
+func TestMap(t *testing.T) {
+	if got := Map(Ok(2), strconv.Itoa).OrElse(""); got != "2" {
+		t.Errorf("Map(Ok(2)) = %q", got)
+	}
+	called := false
+	r := Map(Err[int](errBoom), func(int) string { called = true; return "" })
+	if called || !errors.Is(r.Err(), errBoom) {
+		t.Errorf("Map on a failure called f or lost the error: %+v", r)
+	}
+}
+
+func TestOrElse(t *testing.T) {
+	if got := Ok(1).OrElse(9); got != 1 {
+		t.Errorf("Ok(1).OrElse(9) = %d", got)
+	}
+	if got := Err[int](errBoom).OrElse(9); got != 9 {
+		t.Errorf("Err.OrElse(9) = %d", got)
+	}
+}
+
+func TestMust(t *testing.T) {
+	if got := Ok("x").Must(); got != "x" {
+		t.Errorf("Ok(x).Must() = %q", got)
+	}
+	defer func() {
+		if v := recover(); v != errBoom {
+			t.Errorf("recovered %v, want errBoom", v)
+		}
+	}()
+	Err[string](errBoom).Must()
+	t.Error("Must did not panic on a failure")
+}
+
+func TestSplit(t *testing.T) {
+	values, failures := Split([]Result[int]{Ok(1), Err[int](errBoom), Ok(3)})
+	if len(values) != 3 || values[0] != 1 || values[1] != 0 || values[2] != 3 {
+		t.Errorf("values = %v", values)
+	}
+	if len(failures) != 1 || failures[1] != errBoom {
+		t.Errorf("failures = %v, want only index 1", failures)
+	}
+
+	if _, failures := Split([]Result[int]{Ok(1)}); failures != nil {
+		t.Errorf("all-ok failures = %v, want nil", failures)
+	}
+}